@@ -0,0 +1,88 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// AlchemyProvider implements CollectibleDataProvider against Alchemy's NFT
+// API (getNFTs), for use as a fallback when OpenSea errors out or is
+// rate-limited.
+type AlchemyProvider struct {
+	BaseURL    string
+	httpClient *http.Client
+}
+
+// NewAlchemyProvider builds an AlchemyProvider scoped to apiKey on Ethereum
+// mainnet.
+func NewAlchemyProvider(apiKey string) *AlchemyProvider {
+	return &AlchemyProvider{
+		BaseURL:    fmt.Sprintf("https://eth-mainnet.g.alchemy.com/nft/v2/%s", apiKey),
+		httpClient: defaultHttpClient(),
+	}
+}
+
+type alchemyNFT struct {
+	Contract struct {
+		Address string `json:"address"`
+	} `json:"contract"`
+	ID struct {
+		TokenID string `json:"tokenId"`
+	} `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type alchemyGetNFTsResponse struct {
+	OwnedNFTs []alchemyNFT `json:"ownedNfts"`
+	PageKey   string       `json:"pageKey"`
+}
+
+// FetchAssetsByOwner implements CollectibleDataProvider via Alchemy's
+// getNFTs endpoint, using its pageKey as the cursor.
+func (p *AlchemyProvider) FetchAssetsByOwner(ctx context.Context, owner Address, cursor string, limit int) (*AssetResponse, error) {
+	url := fmt.Sprintf("%s/getNFTs?owner=%s", p.BaseURL, owner.String())
+	if cursor != "" {
+		url += "&pageKey=" + cursor
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parsed alchemyGetNFTsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	ret := &AssetResponse{NextCursor: parsed.PageKey}
+	for _, nft := range parsed.OwnedNFTs {
+		ret.Assets = append(ret.Assets, Asset{
+			TokenID:              nft.ID.TokenID,
+			AssetContractAddress: Address(nft.Contract.Address),
+			Name:                 nft.Title,
+			Description:          nft.Description,
+		})
+	}
+
+	return ret, nil
+}
+
+// FetchAssetsByContractAndTokenIDs is not supported by Alchemy's getNFTs
+// endpoint, which is owner-scoped rather than contract-scoped.
+func (p *AlchemyProvider) FetchAssetsByContractAndTokenIDs(ctx context.Context, contractAddress Address, tokenIDs []int32) ([]Asset, error) {
+	return nil, fmt.Errorf("alchemy: FetchAssetsByContractAndTokenIDs is not supported")
+}
+
+// FetchCollectionsByOwner is not supported by Alchemy's NFT API v2.
+func (p *AlchemyProvider) FetchCollectionsByOwner(ctx context.Context, owner Address, offset, limit int) ([]Collection, error) {
+	return nil, fmt.Errorf("alchemy: FetchCollectionsByOwner is not supported")
+}
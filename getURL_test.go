@@ -0,0 +1,87 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetURLRetriesOnTooManyRequestsThenSucceeds(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"success":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"assets":[]}`))
+	}))
+	defer server.Close()
+
+	o := Opensea{
+		httpClient:    server.Client(),
+		RetryMaxCount: 2,
+		RetryWaitTime: time.Millisecond,
+	}
+
+	body, err := o.getURL(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+	if string(body) != `{"assets":[]}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
+func TestGetURLGivesUpAfterRetryMaxCount(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"success":false}`))
+	}))
+	defer server.Close()
+
+	o := Opensea{
+		httpClient:    server.Client(),
+		RetryMaxCount: 3,
+		RetryWaitTime: time.Millisecond,
+	}
+
+	_, err := o.getURL(context.Background(), server.URL)
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	got := parseRetryAfter("2")
+	if got != 2*time.Second {
+		t.Fatalf("got %v, want %v", got, 2*time.Second)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	at := time.Now().Add(3 * time.Second)
+	got := parseRetryAfter(at.UTC().Format(http.TimeFormat))
+	if got <= 0 || got > 3*time.Second {
+		t.Fatalf("got %v, want a positive duration up to 3s", got)
+	}
+}
+
+func TestParseRetryAfterEmpty(t *testing.T) {
+	if got := parseRetryAfter(""); got != 0 {
+		t.Fatalf("got %v, want 0", got)
+	}
+}
@@ -0,0 +1,51 @@
+package opensea
+
+// Address is a hex-encoded Ethereum address.
+type Address string
+
+// NullAddress is the zero value of Address, used to detect unset fields.
+const NullAddress Address = ""
+
+func (a Address) String() string {
+	return string(a)
+}
+
+// Trait model info
+// @Description A single OpenSea asset trait
+type Trait struct {
+	TraitType   string     `json:"trait_type" bson:"trait_type"`
+	Value       TraitValue `json:"value" bson:"value"`
+	DisplayType string     `json:"display_type" bson:"display_type"`
+}
+
+// Asset model info
+// @Description A single OpenSea asset (NFT)
+type Asset struct {
+	TokenID              string  `json:"token_id" bson:"token_id"`
+	AssetContractAddress Address `json:"asset_contract_address" bson:"asset_contract_address"`
+	Name                 string  `json:"name" bson:"name"`
+	Description          string  `json:"description" bson:"description"`
+	ImageURL             string  `json:"image_url" bson:"image_url"`
+	Permalink            string  `json:"permalink" bson:"permalink"`
+	Collection           string  `json:"collection" bson:"collection"`
+	Traits               []Trait `json:"traits" bson:"traits"`
+}
+
+// AssetResponse model info
+// @Description Response payload of the assets endpoint, including the
+// cursors needed to walk to the next or previous page.
+type AssetResponse struct {
+	Assets         []Asset `json:"assets" bson:"assets"`
+	NextCursor     string  `json:"next" bson:"next"`
+	PreviousCursor string  `json:"previous" bson:"previous"`
+}
+
+// Collection model info
+// @Description Summary of an OpenSea collection, as returned by the
+// collections endpoint
+type Collection struct {
+	Name            string `json:"name" bson:"name"`
+	Slug            string `json:"slug" bson:"slug"`
+	ImageURL        string `json:"image_url" bson:"image_url"`
+	OwnedAssetCount int    `json:"owned_asset_count" bson:"owned_asset_count"`
+}
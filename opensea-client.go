@@ -3,24 +3,98 @@ package opensea
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Chain IDs for the networks OpenSea's v1 API actually serves. OpenSea's v1
+// API has no per-chain path or query parameter and exposes distinct
+// endpoints only for Ethereum mainnet and its public testnets, so there are
+// no IDs here for Polygon, Optimism, Arbitrum or BSC: pointing one of those
+// at the mainnet URL would silently return Ethereum mainnet data under the
+// wrong chain's name, which is worse than not supporting it. Adding real L2
+// support needs an endpoint to scope to first.
+const (
+	ChainIDMainnet uint64 = 1
+	ChainIDRinkeby uint64 = 4
+	ChainIDGoerli  uint64 = 5
 )
 
-var (
-	mainnetAPI = "https://api.opensea.io"
-	rinkebyAPI = "https://rinkeby-api.opensea.io"
+// chainAPIs maps a chain ID to the OpenSea API base URL that serves it.
+var chainAPIs = map[uint64]string{
+	ChainIDMainnet: "https://api.opensea.io",
+	ChainIDRinkeby: "https://rinkeby-api.opensea.io",
+	ChainIDGoerli:  "https://testnets-api.opensea.io",
+}
+
+// chainsRequiringAPIKey are the chains for which OpenSea actually enforces
+// the X-API-KEY header; sending it elsewhere is harmless but unnecessary.
+var chainsRequiringAPIKey = map[uint64]bool{
+	ChainIDMainnet: true,
+}
+
+const (
+	// GetRequestRetryMaxCount is the default number of attempts getURL
+	// makes before giving up on a retryable error.
+	GetRequestRetryMaxCount = 3
+	// GetRequestWaitTime is the default base delay between retries; each
+	// subsequent attempt doubles it and adds jitter.
+	GetRequestWaitTime = 500 * time.Millisecond
+	// RequestTimeout bounds a single HTTP round trip.
+	RequestTimeout = 5 * time.Second
+	// openseaRateLimit mirrors OpenSea's documented ~4 req/s rate cap.
+	openseaRateLimit = 4
 )
 
+// retryableStatusCodes are the HTTP statuses getURL will retry rather than
+// fail on immediately.
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
 type Opensea struct {
 	API        string
 	APIKey     string
+	ChainID    uint64
 	httpClient *http.Client
+
+	// RetryMaxCount, RetryWaitTime and Timeout tune getURL's retry/backoff
+	// and per-request timeout behaviour. Zero values fall back to
+	// GetRequestRetryMaxCount, GetRequestWaitTime and RequestTimeout.
+	RetryMaxCount int
+	RetryWaitTime time.Duration
+	Timeout       time.Duration
+
+	limiter *rate.Limiter
+}
+
+// retryableError marks an error returned by a single getURL attempt as
+// worth retrying, optionally carrying a server-requested Retry-After delay.
+type retryableError struct {
+	retryAfter time.Duration
+	err        error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
 }
 
 // GetAssetsParams model info
@@ -35,6 +109,11 @@ type GetAssetsParams struct {
 	Offset                 int       `json:"offset" bson:"offset"`
 	Limit                  int       `json:"limit" bson:"limit"`
 	Collection             string    `json:"collection" bson:"collection"`
+	// Cursor, when set, is used instead of Offset to page through results,
+	// matching OpenSea's cursor-based pagination (offset is deprecated on
+	// newer API versions). Populate it from AssetResponse.NextCursor /
+	// PreviousCursor.
+	Cursor string `json:"cursor" bson:"cursor"`
 }
 
 type errorResponse struct {
@@ -45,22 +124,45 @@ func (e errorResponse) Error() string {
 	return "Not success"
 }
 
-func NewOpensea(apiKey string) (*Opensea, error) {
+// NewOpenseaForChain builds an Opensea client scoped to chainID, picking the
+// matching API base URL. Only ChainIDMainnet, ChainIDRinkeby and
+// ChainIDGoerli are supported today; it returns an error for any other
+// chain ID, including L2s, rather than silently serving mainnet data under
+// the wrong chain's name.
+func NewOpenseaForChain(chainID uint64, apiKey string) (*Opensea, error) {
+	api, ok := chainAPIs[chainID]
+	if !ok {
+		return nil, fmt.Errorf("opensea: chain id %d has no distinct API endpoint yet, refusing to alias it to mainnet", chainID)
+	}
+
 	o := &Opensea{
-		API:        mainnetAPI,
+		API:        api,
 		APIKey:     apiKey,
+		ChainID:    chainID,
 		httpClient: defaultHttpClient(),
+		limiter:    rate.NewLimiter(rate.Limit(openseaRateLimit), openseaRateLimit),
 	}
 	return o, nil
 }
 
+// SetRetryPolicy overrides the default retry count and base backoff wait
+// used by getURL.
+func (o *Opensea) SetRetryPolicy(maxCount int, waitTime time.Duration) {
+	o.RetryMaxCount = maxCount
+	o.RetryWaitTime = waitTime
+}
+
+// SetTimeout overrides the default per-request timeout applied in getURL.
+func (o *Opensea) SetTimeout(timeout time.Duration) {
+	o.Timeout = timeout
+}
+
+func NewOpensea(apiKey string) (*Opensea, error) {
+	return NewOpenseaForChain(ChainIDMainnet, apiKey)
+}
+
 func NewOpenseaRinkeby(apiKey string) (*Opensea, error) {
-	o := &Opensea{
-		API:        rinkebyAPI,
-		APIKey:     apiKey,
-		httpClient: defaultHttpClient(),
-	}
-	return o, nil
+	return NewOpenseaForChain(ChainIDRinkeby, apiKey)
 }
 
 func (p GetAssetsParams) Encode() string {
@@ -102,44 +204,158 @@ func (p GetAssetsParams) Encode() string {
 	}
 
 	q.Set("limit", fmt.Sprintf("%d", p.Limit))
-	q.Set("offset", fmt.Sprintf("%d", p.Offset))
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	} else {
+		q.Set("offset", fmt.Sprintf("%d", p.Offset))
+	}
 	q.Set("include_orders", "false")
 
 	return q.Encode()
 }
 
 func (o Opensea) GetAssets(params GetAssetsParams) (*AssetResponse, error) {
-	GetAssetsTest()
 	ctx := context.TODO()
 	return o.GetAssetsWithContext(ctx, params)
 }
 
-func GetAssetsTest() {
-	url := "https://api.opensea.io/api/v1/assets?order_direction=desc&limit=20"
+func (o Opensea) GetAssetsWithContext(ctx context.Context, params GetAssetsParams) (*AssetResponse, error) {
+	path := "/api/v1/assets/?" + params.Encode()
+	body, err := o.GetPath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	ret := new(AssetResponse)
+	return ret, json.Unmarshal(body, ret)
+}
 
-	req, _ := http.NewRequest("GET", url, nil)
+// AssetLimit is the largest page size OpenSea's assets endpoint accepts,
+// and the page size GetAllAssets and StreamAssets request internally.
+const AssetLimit = 200
+
+// nextAssetsPage advances params to the next page given the previous
+// response, returning ok=false once pagination is exhausted. It prefers
+// cursor pagination, but falls back to incrementing Offset for endpoints
+// that never populate NextCursor; once a cursor has been seen it commits to
+// cursor pagination for the rest of the walk so the two modes don't mix.
+func nextAssetsPage(params GetAssetsParams, resp *AssetResponse, usingCursor *bool) (GetAssetsParams, bool) {
+	if resp.NextCursor != "" {
+		*usingCursor = true
+		params.Cursor = resp.NextCursor
+		return params, true
+	}
 
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("X-API-KEY", "ad9d3916aa3a409f92a3bbd6aff78e8d")
+	if !*usingCursor && len(resp.Assets) == params.Limit {
+		params.Offset += params.Limit
+		return params, true
+	}
 
-	res, _ := http.DefaultClient.Do(req)
+	return params, false
+}
 
-	defer res.Body.Close()
-	body, _ := ioutil.ReadAll(res.Body)
+// GetAllAssets walks every page of params, preferring cursor pagination and
+// falling back to offset pagination for endpoints that don't populate
+// next/previous, and returns the concatenated assets. It stops early if ctx
+// is cancelled.
+func (o Opensea) GetAllAssets(ctx context.Context, params GetAssetsParams) ([]Asset, error) {
+	params.Limit = AssetLimit
+	usingCursor := params.Cursor != ""
+
+	var all []Asset
+	for {
+		resp, err := o.GetAssetsWithContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Assets...)
 
-	fmt.Println(res)
-	fmt.Println(string(body))
+		next, ok := nextAssetsPage(params, resp, &usingCursor)
+		if !ok {
+			return all, nil
+		}
+		params = next
+	}
 }
 
-func (o Opensea) GetAssetsWithContext(ctx context.Context, params GetAssetsParams) (*AssetResponse, error) {
-	path := "/api/v1/assets/?" + params.Encode()
+// StreamAssets walks every page of params like GetAllAssets, but delivers
+// assets incrementally over a channel as each page arrives instead of
+// waiting for the full listing. Both channels are closed once pagination is
+// exhausted, ctx is cancelled, or an error occurs.
+func (o Opensea) StreamAssets(ctx context.Context, params GetAssetsParams) (<-chan Asset, <-chan error) {
+	assets := make(chan Asset)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(assets)
+		defer close(errs)
+
+		params.Limit = AssetLimit
+		usingCursor := params.Cursor != ""
+		for {
+			resp, err := o.GetAssetsWithContext(ctx, params)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for _, asset := range resp.Assets {
+				select {
+				case assets <- asset:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+
+			next, ok := nextAssetsPage(params, resp, &usingCursor)
+			if !ok {
+				return
+			}
+			params = next
+		}
+	}()
+
+	return assets, errs
+}
+
+// GetAssetsByCollection fetches a page of assets belonging to the collection
+// identified by slug, paging via cursor as returned in a previous
+// AssetResponse.NextCursor/PreviousCursor.
+func (o Opensea) GetAssetsByCollection(slug, cursor string, limit int) (*AssetResponse, error) {
+	ctx := context.TODO()
+	return o.GetAssetsByCollectionWithContext(ctx, slug, cursor, limit)
+}
+
+func (o Opensea) GetAssetsByCollectionWithContext(ctx context.Context, slug, cursor string, limit int) (*AssetResponse, error) {
+	params := GetAssetsParams{
+		Collection: slug,
+		Cursor:     cursor,
+		Limit:      limit,
+	}
+	return o.GetAssetsWithContext(ctx, params)
+}
+
+// GetCollections lists the collections owned by owner.
+func (o Opensea) GetCollections(owner Address, offset, limit int) ([]Collection, error) {
+	ctx := context.TODO()
+	return o.GetCollectionsWithContext(ctx, owner, offset, limit)
+}
+
+func (o Opensea) GetCollectionsWithContext(ctx context.Context, owner Address, offset, limit int) ([]Collection, error) {
+	q := url.Values{}
+	if owner.String() != "" && owner != NullAddress {
+		q.Set("asset_owner", owner.String())
+	}
+	q.Set("offset", fmt.Sprintf("%d", offset))
+	q.Set("limit", fmt.Sprintf("%d", limit))
+
+	path := "/api/v1/collections?" + q.Encode()
 	body, err := o.GetPath(ctx, path)
 	if err != nil {
 		return nil, err
 	}
-	ret := new(AssetResponse)
-	fmt.Println(string(body))
-	return ret, json.Unmarshal(body, ret)
+	ret := make([]Collection, 0)
+	return ret, json.Unmarshal(body, &ret)
 }
 
 func (o Opensea) GetSingleAsset(assetContractAddress string, tokenID *big.Int) (*Asset, error) {
@@ -161,13 +377,70 @@ func (o Opensea) GetPath(ctx context.Context, path string) ([]byte, error) {
 	return o.getURL(ctx, o.API+path)
 }
 
+// getURL performs a single logical GET, retrying on 429/5xx responses with
+// exponential backoff (honoring Retry-After when present) and rate-limiting
+// outgoing requests so bursts stay under OpenSea's cap. Each individual
+// attempt is bounded by o.Timeout (default RequestTimeout).
 func (o Opensea) getURL(ctx context.Context, url string) ([]byte, error) {
+	maxCount := o.RetryMaxCount
+	if maxCount <= 0 {
+		maxCount = GetRequestRetryMaxCount
+	}
+	waitTime := o.RetryWaitTime
+	if waitTime <= 0 {
+		waitTime = GetRequestWaitTime
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxCount; attempt++ {
+		if o.limiter != nil {
+			if err := o.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := o.getURLOnce(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		var retryable *retryableError
+		if !errors.As(err, &retryable) || attempt == maxCount-1 {
+			return nil, err
+		}
+
+		wait := retryable.retryAfter
+		if wait <= 0 {
+			wait = backoffWithJitter(waitTime, attempt)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return nil, lastErr
+}
+
+// getURLOnce performs a single attempt at url, wrapping ctx with o.Timeout.
+func (o Opensea) getURLOnce(ctx context.Context, url string) ([]byte, error) {
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = RequestTimeout
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	client := o.httpClient
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(reqCtx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("X-API-KEY", o.APIKey)
+	if chainsRequiringAPIKey[o.ChainID] {
+		req.Header.Add("X-API-KEY", o.APIKey)
+	}
 	req.Header.Add("Accept", "application/json")
 	resp, err := client.Do(req)
 	if err != nil {
@@ -181,21 +454,47 @@ func (o Opensea) getURL(ctx context.Context, url string) ([]byte, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+
 		e := new(errorResponse)
-		err = json.Unmarshal(body, e)
-		if err != nil {
-			return nil, err
-		}
-		if !e.Success {
+		if jsonErr := json.Unmarshal(body, e); jsonErr == nil && !e.Success {
+			if retryableStatusCodes[resp.StatusCode] {
+				return nil, &retryableError{retryAfter: retryAfter, err: e}
+			}
 			return nil, e
 		}
 
-		return nil, fmt.Errorf("backend returns status %d msg: %s", resp.StatusCode, string(body))
+		backendErr := fmt.Errorf("backend returns status %d msg: %s", resp.StatusCode, string(body))
+		if retryableStatusCodes[resp.StatusCode] {
+			return nil, &retryableError{retryAfter: retryAfter, err: backendErr}
+		}
+		return nil, backendErr
 	}
 
 	return body, nil
 }
 
+// parseRetryAfter parses a Retry-After header expressed as either a delay
+// in seconds or an HTTP date, returning 0 if it's absent or unparseable.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
+
+// backoffWithJitter doubles base for every prior attempt and adds up to
+// base of random jitter, to avoid retry storms across concurrent callers.
+func backoffWithJitter(base time.Duration, attempt int) time.Duration {
+	return base<<uint(attempt) + time.Duration(rand.Int63n(int64(base)))
+}
+
 func (o Opensea) SetHttpClient(httpClient *http.Client) {
 	o.httpClient = httpClient
 }
@@ -0,0 +1,105 @@
+package opensea
+
+import "context"
+
+// CollectibleDataProvider abstracts fetching NFT ownership and collection
+// data so callers can register multiple backends (OpenSea, Alchemy,
+// Infura, ...) and fall through when one errors out or is rate-limited.
+type CollectibleDataProvider interface {
+	FetchAssetsByOwner(ctx context.Context, owner Address, cursor string, limit int) (*AssetResponse, error)
+	FetchAssetsByContractAndTokenIDs(ctx context.Context, contractAddress Address, tokenIDs []int32) ([]Asset, error)
+	FetchCollectionsByOwner(ctx context.Context, owner Address, offset, limit int) ([]Collection, error)
+}
+
+// FetchAssetsByOwner implements CollectibleDataProvider.
+func (o *Opensea) FetchAssetsByOwner(ctx context.Context, owner Address, cursor string, limit int) (*AssetResponse, error) {
+	return o.GetAssetsWithContext(ctx, GetAssetsParams{
+		Owner:  owner,
+		Cursor: cursor,
+		Limit:  limit,
+	})
+}
+
+// FetchAssetsByContractAndTokenIDs implements CollectibleDataProvider,
+// paging through cursor pagination until every requested token ID has had a
+// chance to come back (OpenSea's page size is capped at AssetLimit, which
+// can be smaller than len(tokenIDs)).
+func (o *Opensea) FetchAssetsByContractAndTokenIDs(ctx context.Context, contractAddress Address, tokenIDs []int32) ([]Asset, error) {
+	limit := len(tokenIDs)
+	if limit <= 0 || limit > AssetLimit {
+		limit = AssetLimit
+	}
+
+	params := GetAssetsParams{
+		AssetContractAddress: contractAddress,
+		TokenIDs:             tokenIDs,
+		Limit:                limit,
+	}
+
+	var assets []Asset
+	for {
+		resp, err := o.GetAssetsWithContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, resp.Assets...)
+
+		if resp.NextCursor == "" || len(assets) >= len(tokenIDs) {
+			return assets, nil
+		}
+		params.Cursor = resp.NextCursor
+	}
+}
+
+// FetchCollectionsByOwner implements CollectibleDataProvider.
+func (o *Opensea) FetchCollectionsByOwner(ctx context.Context, owner Address, offset, limit int) ([]Collection, error) {
+	return o.GetCollectionsWithContext(ctx, owner, offset, limit)
+}
+
+// FallbackProvider wraps an ordered list of CollectibleDataProvider and, on
+// each call, tries them in order until one succeeds.
+type FallbackProvider struct {
+	Providers []CollectibleDataProvider
+}
+
+// NewFallbackProvider builds a FallbackProvider that tries providers in the
+// given order.
+func NewFallbackProvider(providers ...CollectibleDataProvider) *FallbackProvider {
+	return &FallbackProvider{Providers: providers}
+}
+
+func (f *FallbackProvider) FetchAssetsByOwner(ctx context.Context, owner Address, cursor string, limit int) (*AssetResponse, error) {
+	var lastErr error
+	for _, p := range f.Providers {
+		ret, err := p.FetchAssetsByOwner(ctx, owner, cursor, limit)
+		if err == nil {
+			return ret, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (f *FallbackProvider) FetchAssetsByContractAndTokenIDs(ctx context.Context, contractAddress Address, tokenIDs []int32) ([]Asset, error) {
+	var lastErr error
+	for _, p := range f.Providers {
+		ret, err := p.FetchAssetsByContractAndTokenIDs(ctx, contractAddress, tokenIDs)
+		if err == nil {
+			return ret, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (f *FallbackProvider) FetchCollectionsByOwner(ctx context.Context, owner Address, offset, limit int) ([]Collection, error) {
+	var lastErr error
+	for _, p := range f.Providers {
+		ret, err := p.FetchCollectionsByOwner(ctx, owner, offset, limit)
+		if err == nil {
+			return ret, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
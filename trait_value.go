@@ -0,0 +1,41 @@
+package opensea
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// TraitValue normalizes OpenSea trait values, which the API returns
+// interchangeably as strings, ints and floats, into a single comparable
+// string type. Floats are formatted with 2 decimal places, ints in base 10,
+// and strings are used verbatim.
+type TraitValue string
+
+func (v *TraitValue) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch value := raw.(type) {
+	case nil:
+		*v = ""
+	case string:
+		*v = TraitValue(value)
+	case float64:
+		if value == float64(int64(value)) {
+			*v = TraitValue(strconv.FormatInt(int64(value), 10))
+		} else {
+			*v = TraitValue(strconv.FormatFloat(value, 'f', 2, 64))
+		}
+	default:
+		return fmt.Errorf("opensea: unsupported trait value type %T", raw)
+	}
+
+	return nil
+}
+
+func (v TraitValue) String() string {
+	return string(v)
+}
@@ -0,0 +1,30 @@
+package opensea
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTraitValueUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want TraitValue
+	}{
+		{"string", `{"trait_type":"Background","value":"Blue"}`, "Blue"},
+		{"int", `{"trait_type":"Level","value":7}`, "7"},
+		{"float", `{"trait_type":"Rank","value":3.5}`, "3.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var trait Trait
+			if err := json.Unmarshal([]byte(tt.json), &trait); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if trait.Value != tt.want {
+				t.Errorf("got %q, want %q", trait.Value, tt.want)
+			}
+		})
+	}
+}